@@ -1,35 +1,54 @@
 package channels
 
+import (
+	"context"
+	"time"
+)
+
 // BatchingChannel implements the Channel interface, with the change that instead of producing individual elements
 // on Out(), it batches together the entire internal buffer each time. Trying to construct an unbuffered batching channel
 // will panic, that configuration is not supported (and provides no benefit over an unbuffered NativeChannel).
+//
+// When constructed via NewTimeBatchingChannel with a non-zero maxDelay, a batch is also flushed once maxDelay has
+// elapsed since the first element of the current batch was enqueued, even if size has not yet been reached - this
+// bounds the latency of elements sitting in the channel when producers are slower than size.
+//
+// BatchingChannel is a facade around batchingCore[interface{}, interface{}]; see that type for the buffering/timer/
+// overflow logic itself, which is shared with the generic Batching[T].
 type BatchingChannel struct {
-	input, output chan interface{}
-	length        chan int
-	buffer        []interface{}
-	size          BufferCap
+	core *batchingCore[interface{}, interface{}]
 }
 
+// NewBatchingChannel returns a BatchingChannel that emits a batch as soon as size elements have accumulated (or,
+// for Infinity, whenever a reader is ready). It is equivalent to NewTimeBatchingChannel(size, 0, 0), i.e. no
+// timeout-based flushing.
 func NewBatchingChannel(size BufferCap) *BatchingChannel {
-	if size == None {
-		panic("channels: BatchingChannel does not support unbuffered behaviour")
-	}
-	if size < 0 && size != Infinity {
-		panic("channels: invalid negative size in NewBatchingChannel")
-	}
-	ch := &BatchingChannel{
-		input:  make(chan interface{}),
-		output: make(chan interface{}),
-		length: make(chan int),
-		size:   size,
+	return NewTimeBatchingChannel(size, 0, 0)
+}
+
+// NewTimeBatchingChannel behaves like NewBatchingChannel, but additionally flushes the current batch once maxDelay
+// has elapsed since its first element was enqueued, even if size has not yet been reached. A maxDelay of 0 disables
+// the timeout and reproduces the original, count-only behaviour of NewBatchingChannel. minSize, if greater than
+// zero, postpones a timeout-triggered flush until the batch holds at least minSize elements, so that a timer firing
+// immediately after a single element doesn't force out a near-empty batch; it has no effect on a flush triggered by
+// reaching size, and is ignored when maxDelay is 0.
+func NewTimeBatchingChannel(size BufferCap, maxDelay time.Duration, minSize int) *BatchingChannel {
+	return NewBatchingChannelWithOverflow(size, maxDelay, minSize, Block)
+}
+
+// NewBatchingChannelWithOverflow behaves like NewTimeBatchingChannel, but lets the caller pick what happens once a
+// finite size is reached, via policy. Block (the default used by NewBatchingChannel/NewTimeBatchingChannel) and
+// Error both make In()/Send() block until the reader drains room, and TrySend returns ErrFull under either of them
+// once full; the two behave identically. DropNewest and DropOldest never block, discarding an element instead; the number discarded is available
+// from Stats(). DropNewest and DropOldest require a finite size, since an Infinity channel is never full.
+func NewBatchingChannelWithOverflow(size BufferCap, maxDelay time.Duration, minSize int, policy OverflowPolicy) *BatchingChannel {
+	return &BatchingChannel{
+		core: newBatchingCore[interface{}, interface{}](size, maxDelay, minSize, policy),
 	}
-	go ch.batchingBuffer()
-	return ch
 }
 
-// 返回可写的buffer
 func (ch *BatchingChannel) In() chan<- interface{} {
-	return ch.input
+	return ch.core.input
 }
 
 // Out returns a <-chan interface{} in order that BatchingChannel conforms to the standard Channel interface provided
@@ -37,60 +56,85 @@ func (ch *BatchingChannel) In() chan<- interface{} {
 // recent batch of values sent on the In channel. The slice is guaranteed to not be empty or nil. In practice the net
 // result is that you need an additional type assertion to access the underlying values.
 func (ch *BatchingChannel) Out() <-chan interface{} {
-	return ch.output
+	return ch.core.output
 }
 
 func (ch *BatchingChannel) Len() int {
-	return <-ch.length
+	return <-ch.core.length
 }
 
 func (ch *BatchingChannel) Cap() BufferCap {
-	return ch.size
+	return ch.core.size
+}
+
+// Stats reports the channel's overflow counters. Dropped is always 0 unless the channel was constructed with
+// NewBatchingChannelWithOverflow and a DropNewest or DropOldest policy.
+func (ch *BatchingChannel) Stats() Stats {
+	return ch.core.Stats()
 }
 
 func (ch *BatchingChannel) Close() {
-	close(ch.input)
+	close(ch.core.input)
 }
 
-func (ch *BatchingChannel) batchingBuffer() {
-	var input, output, nextInput chan interface{}
-    // channel是引用类型
-	nextInput = ch.input
-	input = nextInput
-
-    // 核心的input和output
-	for input != nil || output != nil {
-		select {
-            // open表示器是否已经close了
-		case elem, open := <-input:
-			if open {
-                // 写进来放到buffer中
-				ch.buffer = append(ch.buffer, elem)
-			} else { // 写端关闭
-				input = nil
-				nextInput = nil
-			}
-		case output <- ch.buffer: // 刚开始是nil，那就是是阻塞的
-            // 读完一个就置为nil?
-			ch.buffer = nil
-		case ch.length <- len(ch.buffer):
-		}
+// TryIn implements ContextChannel.
+func (ch *BatchingChannel) TryIn(v interface{}) bool {
+	select {
+	case ch.core.input <- v:
+		return true
+	default:
+		return false
+	}
+}
 
-        // 读完了
-		if len(ch.buffer) == 0 {
-			input = nextInput
-            // 读端再次设置为nil, 为阻塞
-			output = nil
-		} else if ch.size != Infinity && len(ch.buffer) >= int(ch.size) { // 至多写size个数据
-			input = nil  // 设置写端为阻塞的
-			output = ch.output // 然后开始读
-		} else {  // 其他情况，即可写，又可读
-			input = nextInput
-			output = ch.output
-		}
+// TryOut implements ContextChannel. Without blocking, it returns the current batch only if the core is offering a
+// non-empty batch on Out() right now; with maxDelay > 0, a batch below size is not offered until maxDelay has
+// elapsed (and minSize is met). That batch is not guaranteed to be "ready" by size or maxDelay, since the core's run loop makes ch.core.output
+// selectable as soon as the buffer holds anything when neither of those conditions has been configured to gate it
+// (e.g. a plain NewBatchingChannel, where maxDelay is 0). Callers that need full-size batches must not assume
+// TryOut only returns one once size has been reached.
+func (ch *BatchingChannel) TryOut() (interface{}, bool) {
+	select {
+	case batch, open := <-ch.core.output:
+		return batch, open
+	default:
+		return nil, false
+	}
+}
+
+// Send implements ContextChannel. The select below is atomic, so a cancelled ctx never leaves v half-enqueued.
+func (ch *BatchingChannel) Send(ctx context.Context, v interface{}) error {
+	// An already-done ctx wins outright; otherwise the select could randomly pick a ready input instead.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case ch.core.input <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-    // 关闭读和length channel
-	close(ch.output)
-	close(ch.length)
+// TrySend attempts to enqueue v without blocking. For a channel constructed with the Error overflow policy, it
+// returns ErrFull once the channel has reached its size instead of blocking; for every other policy it is
+// equivalent to TryIn, wrapped in the same error shape for convenience.
+func (ch *BatchingChannel) TrySend(v interface{}) error {
+	if ch.TryIn(v) {
+		return nil
+	}
+	return ErrFull
+}
+
+// Recv implements ContextChannel.
+func (ch *BatchingChannel) Recv(ctx context.Context) (interface{}, error) {
+	select {
+	case batch, open := <-ch.core.output:
+		if !open {
+			return nil, ErrClosed
+		}
+		return batch, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
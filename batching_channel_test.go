@@ -0,0 +1,71 @@
+package channels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloseFlushesPendingTimedBatch(t *testing.T) {
+	ch := NewTimeBatchingChannel(BufferCap(10), 5*time.Second, 0)
+	ch.In() <- "a"
+	ch.In() <- "b"
+	ch.Close()
+
+	select {
+	case batch, open := <-ch.Out():
+		if !open {
+			t.Fatal("Out() closed before delivering the pending batch")
+		}
+		got := batch.([]interface{})
+		if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Fatalf("unexpected batch %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Out() did not deliver the pending batch after Close()")
+	}
+
+	if _, open := <-ch.Out(); open {
+		t.Fatal("expected Out() to be closed after the final batch")
+	}
+}
+
+func TestMaxDelayFlushesSubSizeBatchWithoutClose(t *testing.T) {
+	ch := NewTimeBatchingChannel(BufferCap(10), 20*time.Millisecond, 0)
+	ch.In() <- "a"
+
+	select {
+	case batch := <-ch.Out():
+		got := batch.([]interface{})
+		if len(got) != 1 || got[0] != "a" {
+			t.Fatalf("unexpected batch %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Out() did not deliver the batch once maxDelay elapsed")
+	}
+	ch.Close()
+}
+
+func TestMaxDelayMinSizeDelaysFlushUntilReached(t *testing.T) {
+	ch := NewTimeBatchingChannel(BufferCap(10), 20*time.Millisecond, 2)
+	ch.In() <- "a"
+
+	// The timer fires well before a second element arrives, but minSize should hold the batch back.
+	select {
+	case batch := <-ch.Out():
+		t.Fatalf("expected no flush below minSize, got %v", batch)
+	case <-time.After(60 * time.Millisecond):
+	}
+
+	ch.In() <- "b"
+
+	select {
+	case batch := <-ch.Out():
+		got := batch.([]interface{})
+		if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Fatalf("unexpected batch %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Out() did not deliver the batch once minSize was reached")
+	}
+	ch.Close()
+}
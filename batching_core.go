@@ -0,0 +1,177 @@
+package channels
+
+import "time"
+
+// batchingCore is the shared batching state machine behind BatchingChannel and Batching[T]: it accumulates
+// elements of type T into batches of up to size, applying the overflow policy and timeout rules described on
+// NewBatchingChannelWithOverflow/NewTimeBatchingChannel, and makes each batch available on output once it's ready.
+// Keeping the logic in one generic type means it only has to be implemented, and tested, once for both facades.
+//
+// B is the batch type delivered on output: []T for the generic Batching[T] facade, or interface{} for the legacy
+// BatchingChannel facade, whose Out() predates generics and can only hand out a boxed []interface{}. Parameterizing
+// over both lets output be fed directly from this type's own select loop for either facade - there is no
+// intermediate forwarding goroutine, which matters because such a goroutine would eagerly drain a batch off output
+// before any caller was actually ready to receive it, defeating batching.
+type batchingCore[T, B any] struct {
+	input     chan T
+	output    chan B
+	length    chan int
+	stats     chan Stats
+	buffer    []T
+	size      BufferCap
+	maxDelay  time.Duration
+	minSize   int
+	overflow  OverflowPolicy
+	dropped   uint64
+	lastStats Stats
+}
+
+func newBatchingCore[T, B any](size BufferCap, maxDelay time.Duration, minSize int, overflow OverflowPolicy) *batchingCore[T, B] {
+	if size == None {
+		panic("channels: batching channel does not support unbuffered behaviour")
+	}
+	if size < 0 && size != Infinity {
+		panic("channels: invalid negative size in batching channel constructor")
+	}
+	if maxDelay < 0 {
+		panic("channels: invalid negative maxDelay in batching channel constructor")
+	}
+	if size == Infinity && (overflow == DropNewest || overflow == DropOldest) {
+		panic("channels: DropNewest/DropOldest overflow policies require a finite size")
+	}
+	c := &batchingCore[T, B]{
+		input:    make(chan T),
+		output:   make(chan B),
+		length:   make(chan int),
+		stats:    make(chan Stats),
+		size:     size,
+		maxDelay: maxDelay,
+		minSize:  minSize,
+		overflow: overflow,
+	}
+	go c.run()
+	return c
+}
+
+// Stats returns the cumulative overflow counters. It keeps returning the last observed value after the channel has
+// been closed and drained, rather than the zero value a plain receive on a closed channel would give - so that a
+// caller logging final stats right after Close() still sees the real count.
+func (c *batchingCore[T, B]) Stats() Stats {
+	s, open := <-c.stats
+	if !open {
+		return c.lastStats
+	}
+	return s
+}
+
+// boxBatch converts a []T into the output type B: for the generic facade B is []T itself (a no-op assertion), and
+// for the legacy interface{} facade B is interface{}, so this is where the boxing that the old BatchingChannel did
+// implicitly via `output <- ch.buffer` now happens explicitly.
+func boxBatch[T, B any](buf []T) B {
+	var v any = buf
+	return v.(B)
+}
+
+func (c *batchingCore[T, B]) run() {
+	var input, nextInput chan T
+	var output chan B
+	nextInput = c.input
+	input = nextInput
+
+	// timer/timerC stay nil when maxDelay is 0, which disables the timeout case in the select below entirely.
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	if c.maxDelay > 0 {
+		timer = time.NewTimer(c.maxDelay)
+		stopTimer(timer)
+		timerC = timer.C
+	}
+	timedOut := false
+
+	for input != nil || output != nil {
+		select {
+		case elem, open := <-input:
+			if open {
+				full := c.size != Infinity && len(c.buffer) >= int(c.size)
+				switch {
+				case full && c.overflow == DropNewest:
+					c.dropped++
+				case full && c.overflow == DropOldest:
+					c.buffer = append(c.buffer[1:], elem)
+					c.dropped++
+				default:
+					if c.maxDelay > 0 && len(c.buffer) == 0 {
+						timer.Reset(c.maxDelay)
+					}
+					c.buffer = append(c.buffer, elem)
+				}
+			} else {
+				input = nil
+				nextInput = nil
+			}
+		case output <- boxBatch[T, B](c.buffer):
+			c.buffer = nil
+			timedOut = false
+			if timer != nil {
+				stopTimer(timer)
+			}
+		case c.length <- len(c.buffer):
+		case c.stats <- Stats{Dropped: c.dropped}:
+		case <-timerC:
+			timedOut = true
+		}
+
+		switch {
+		case len(c.buffer) == 0:
+			input = nextInput
+			output = nil
+		case nextInput == nil:
+			// input is closed and the buffer can never grow again: flush whatever is left immediately,
+			// ignoring size/timer/minSize, or it would be lost once output (and then the channel) closes.
+			input = nil
+			output = c.output
+		case c.size != Infinity && len(c.buffer) >= int(c.size):
+			// The batch is complete regardless of overflow policy, so it must be offered on output right away -
+			// otherwise DropNewest/DropOldest would keep discarding/evicting elements for up to maxDelay even
+			// though a reader is already waiting for this full batch. Block/Error additionally stop accepting
+			// more input until the reader drains room; DropNewest/DropOldest never block, so input stays open to
+			// keep applying the policy to further sends.
+			if c.overflow == Block || c.overflow == Error {
+				input = nil
+			} else {
+				input = nextInput
+			}
+			output = c.output
+		case c.maxDelay > 0:
+			if timedOut && len(c.buffer) >= c.minSize {
+				output = c.output
+			} else {
+				output = nil
+			}
+			input = nextInput
+		default:
+			input = nextInput
+			output = c.output
+		}
+
+		c.lastStats = Stats{Dropped: c.dropped}
+	}
+
+	if timer != nil {
+		timer.Stop()
+	}
+
+	close(c.output)
+	close(c.length)
+	close(c.stats)
+}
+
+// stopTimer stops t and drains a pending fire so a later Reset starts from a clean slate.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package channels
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed is returned by ContextChannel's Send and Recv once the channel has been closed.
+var ErrClosed = errors.New("channels: channel closed")
+
+// ContextChannel extends Channel with non-blocking and context-aware variants of In()/Out(). A plain select on
+// Out() cannot see values held by the buffering goroutine behind Channel, so there was previously no way to poll a
+// channel, or to give up on a send/receive after a deadline, without blocking forever; ContextChannel fixes that
+// for the channel types that implement it.
+type ContextChannel interface {
+	Channel
+
+	// TryIn attempts to send v without blocking, and reports whether it was accepted.
+	TryIn(v interface{}) bool
+
+	// TryOut attempts to receive a value without blocking. ok is false if no value was available.
+	TryOut() (value interface{}, ok bool)
+
+	// Send sends v, blocking until it is accepted or ctx is done. If ctx is done first, Send returns ctx.Err()
+	// and v is not enqueued.
+	Send(ctx context.Context, v interface{}) error
+
+	// Recv receives a value, blocking until one is available or ctx is done. If ctx is done first, Recv returns
+	// ctx.Err(). Once the channel is closed and drained, Recv returns ErrClosed.
+	Recv(ctx context.Context) (interface{}, error)
+}
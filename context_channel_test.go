@@ -0,0 +1,102 @@
+package channels
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInfiniteChannelTryInTryOut(t *testing.T) {
+	ch := NewInfiniteChannel()
+
+	if _, ok := ch.TryOut(); ok {
+		t.Fatal("TryOut() on an empty channel should report ok=false")
+	}
+
+	// Give the internal goroutine a chance to start selecting on input before the non-blocking send below.
+	time.Sleep(10 * time.Millisecond)
+	if !ch.TryIn(1) {
+		t.Fatal("TryIn() should accept when the channel is unbounded")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	v, ok := ch.TryOut()
+	if !ok || v != 1 {
+		t.Fatalf("expected (1, true), got (%v, %v)", v, ok)
+	}
+	ch.Close()
+}
+
+func TestInfiniteChannelSendRecvContextCancellation(t *testing.T) {
+	ch := NewInfiniteChannel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := ch.Send(ctx, 1); err != context.Canceled {
+		t.Fatalf("expected Send to report context.Canceled, got %v", err)
+	}
+
+	if _, err := ch.Recv(ctx); err != context.Canceled {
+		t.Fatalf("expected Recv to report context.Canceled, got %v", err)
+	}
+	ch.Close()
+}
+
+func TestInfiniteChannelRecvErrClosed(t *testing.T) {
+	ch := NewInfiniteChannel()
+	ch.Close()
+
+	if _, err := ch.Recv(context.Background()); err != ErrClosed {
+		t.Fatalf("expected ErrClosed once drained, got %v", err)
+	}
+}
+
+func TestBatchingChannelTrySendErrFull(t *testing.T) {
+	ch := NewBatchingChannelWithOverflow(BufferCap(1), 0, 0, Error)
+	ch.In() <- 1
+	time.Sleep(10 * time.Millisecond)
+
+	if err := ch.TrySend(2); err != ErrFull {
+		t.Fatalf("expected ErrFull once at capacity, got %v", err)
+	}
+	ch.Close()
+}
+
+func TestBatchingChannelTryOut(t *testing.T) {
+	ch := NewBatchingChannel(BufferCap(5))
+
+	if _, ok := ch.TryOut(); ok {
+		t.Fatal("TryOut() on a channel with no pending batch should report ok=false")
+	}
+
+	ch.In() <- 1
+	time.Sleep(10 * time.Millisecond)
+
+	batch, ok := ch.TryOut()
+	if !ok {
+		t.Fatal("TryOut() should report ok=true once the buffer is non-empty, even below size")
+	}
+	if got := batch.([]interface{}); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected batch [1], got %v", got)
+	}
+	ch.Close()
+}
+
+func TestBatchingChannelSendContextCancellation(t *testing.T) {
+	ch := NewBatchingChannelWithOverflow(BufferCap(1), 0, 0, Block)
+	ch.In() <- 1 // fills the one slot, so a further Send blocks until drained
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := ch.Send(ctx, 2); err != context.Canceled {
+		t.Fatalf("expected Send to report context.Canceled, got %v", err)
+	}
+
+	batch := (<-ch.Out()).([]interface{})
+	if len(batch) != 1 || batch[0] != 1 {
+		t.Fatalf("expected the cancelled Send to leave no element enqueued, got batch %v", batch)
+	}
+	ch.Close()
+}
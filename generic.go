@@ -0,0 +1,99 @@
+package channels
+
+import "time"
+
+// This file provides a generics-based parallel API to the interface{} types defined elsewhere in this package
+// (BatchingChannel, and friends). The interface{} types are kept as-is for backward compatibility, and Native[T],
+// Infinite[T] and Batching[T] remove the type assertion that e.g. BatchingChannel.Out() requires, so prefer them in
+// new code where only In()/Out()/Len()/Cap()/Close() are needed. They are not yet at full parity, though: the
+// ContextChannel methods (TryIn/TryOut/Send/Recv) and BatchingChannel's overflow policies are only implemented on
+// the interface{} types for now. The design mirrors golang.design/x/chann.
+
+// TypedChannel is the generic counterpart of Channel: same shape, but In()/Out() are typed on T instead of
+// interface{}.
+type TypedChannel[T any] interface {
+	In() chan<- T
+	Out() <-chan T
+	Len() int
+	Cap() BufferCap
+	Close()
+}
+
+// Native[T] is a thin, typed wrapper around a plain Go channel, provided so it can be used interchangeably with
+// Infinite[T] and Batching[T] through the TypedChannel[T] interface. It does not support Infinity; use Infinite[T]
+// for an unbounded channel.
+type Native[T any] struct {
+	ch chan T
+}
+
+// NewNative creates a new Native[T] with the given buffer size. size must be None (unbuffered) or a positive
+// BufferCap; Infinity is not supported since a native Go channel cannot be grown without bound.
+func NewNative[T any](size BufferCap) *Native[T] {
+	if size < 0 {
+		panic("channels: invalid negative size in NewNative, use Infinite for an unbounded channel")
+	}
+	return &Native[T]{ch: make(chan T, int(size))}
+}
+
+func (ch *Native[T]) In() chan<- T { return ch.ch }
+
+func (ch *Native[T]) Out() <-chan T { return ch.ch }
+
+func (ch *Native[T]) Len() int { return len(ch.ch) }
+
+func (ch *Native[T]) Cap() BufferCap { return BufferCap(cap(ch.ch)) }
+
+func (ch *Native[T]) Close() { close(ch.ch) }
+
+// Infinite[T] is the typed counterpart of an unbounded channel: In() never blocks on a full buffer because the
+// internal buffer grows to hold whatever has not yet been read by Out(). It is a facade around infiniteCore[T],
+// the same buffering engine InfiniteChannel uses internally.
+type Infinite[T any] struct {
+	core *infiniteCore[T]
+}
+
+// NewInfinite creates a new Infinite[T].
+func NewInfinite[T any]() *Infinite[T] {
+	return &Infinite[T]{core: newInfiniteCore[T]()}
+}
+
+func (ch *Infinite[T]) In() chan<- T { return ch.core.input }
+
+func (ch *Infinite[T]) Out() <-chan T { return ch.core.output }
+
+func (ch *Infinite[T]) Len() int { return <-ch.core.length }
+
+func (ch *Infinite[T]) Cap() BufferCap { return Infinity }
+
+func (ch *Infinite[T]) Close() { close(ch.core.input) }
+
+// Batching[T] is the typed counterpart of BatchingChannel: Out() yields []T batches directly, with no type
+// assertion required to get at the underlying values. It is a facade around batchingCore[T, []T], the same
+// buffering engine BatchingChannel uses internally.
+type Batching[T any] struct {
+	core *batchingCore[T, []T]
+}
+
+// NewBatching creates a new Batching[T] that emits a batch as soon as size elements have accumulated (or, for
+// Infinity, whenever a reader is ready). It is equivalent to NewTimeBatching[T](size, 0, 0).
+func NewBatching[T any](size BufferCap) *Batching[T] {
+	return NewTimeBatching[T](size, 0, 0)
+}
+
+// NewTimeBatching behaves like NewBatching, but additionally flushes the current batch once maxDelay has elapsed
+// since its first element was enqueued, even if size has not yet been reached. See NewTimeBatchingChannel for the
+// semantics of maxDelay and minSize.
+func NewTimeBatching[T any](size BufferCap, maxDelay time.Duration, minSize int) *Batching[T] {
+	return &Batching[T]{core: newBatchingCore[T, []T](size, maxDelay, minSize, Block)}
+}
+
+func (ch *Batching[T]) In() chan<- T { return ch.core.input }
+
+// Out returns a <-chan []T: each receive yields the next batch, guaranteed to be non-empty.
+func (ch *Batching[T]) Out() <-chan []T { return ch.core.output }
+
+func (ch *Batching[T]) Len() int { return <-ch.core.length }
+
+func (ch *Batching[T]) Cap() BufferCap { return ch.core.size }
+
+func (ch *Batching[T]) Close() { close(ch.core.input) }
@@ -0,0 +1,46 @@
+package channels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenericBatchingCloseFlushesPendingBatch(t *testing.T) {
+	ch := NewTimeBatching[string](BufferCap(10), 5*time.Second, 0)
+	ch.In() <- "a"
+	ch.In() <- "b"
+	ch.Close()
+
+	select {
+	case batch, open := <-ch.Out():
+		if !open {
+			t.Fatal("Out() closed before delivering the pending batch")
+		}
+		if len(batch) != 2 || batch[0] != "a" || batch[1] != "b" {
+			t.Fatalf("unexpected batch %v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Out() did not deliver the pending batch after Close()")
+	}
+}
+
+func TestGenericBatchingCountFlush(t *testing.T) {
+	ch := NewBatching[int](BufferCap(3))
+	for i := 0; i < 3; i++ {
+		ch.In() <- i
+	}
+	batch := <-ch.Out()
+	if len(batch) != 3 {
+		t.Fatalf("expected batch of 3, got %v", batch)
+	}
+	ch.Close()
+}
+
+func TestNativeRoundTrip(t *testing.T) {
+	ch := NewNative[int](BufferCap(1))
+	ch.In() <- 42
+	if v := <-ch.Out(); v != 42 {
+		t.Fatalf("expected 42 got %d", v)
+	}
+	ch.Close()
+}
@@ -0,0 +1,86 @@
+package channels
+
+import "context"
+
+// InfiniteChannel implements the Channel interface with an unbounded internal buffer between In() and Out(), so
+// that sending on In() never blocks no matter how far the reader on Out() has fallen behind. The buffer is a
+// growable ring buffer (see ringBuffer) rather than a slice that is repeatedly appended to and then discarded
+// wholesale, which keeps steady-state allocations low when producers burst ahead of a slower consumer.
+//
+// InfiniteChannel is a facade around infiniteCore[interface{}]; see that type for the buffering logic itself,
+// which is shared with the generic Infinite[T].
+type InfiniteChannel struct {
+	core *infiniteCore[interface{}]
+}
+
+func NewInfiniteChannel() *InfiniteChannel {
+	return &InfiniteChannel{core: newInfiniteCore[interface{}]()}
+}
+
+func (ch *InfiniteChannel) In() chan<- interface{} {
+	return ch.core.input
+}
+
+func (ch *InfiniteChannel) Out() <-chan interface{} {
+	return ch.core.output
+}
+
+func (ch *InfiniteChannel) Len() int {
+	return <-ch.core.length
+}
+
+func (ch *InfiniteChannel) Cap() BufferCap {
+	return Infinity
+}
+
+func (ch *InfiniteChannel) Close() {
+	close(ch.core.input)
+}
+
+// TryIn implements ContextChannel. Since InfiniteChannel never applies backpressure on its own (the buffer grows
+// to hold whatever In() produces), this only fails while the internal goroutine is mid-select on something else.
+func (ch *InfiniteChannel) TryIn(v interface{}) bool {
+	select {
+	case ch.core.input <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+// TryOut implements ContextChannel.
+func (ch *InfiniteChannel) TryOut() (interface{}, bool) {
+	select {
+	case v, open := <-ch.core.output:
+		return v, open
+	default:
+		return nil, false
+	}
+}
+
+// Send implements ContextChannel. The select below is atomic, so a cancelled ctx never leaves v half-enqueued.
+func (ch *InfiniteChannel) Send(ctx context.Context, v interface{}) error {
+	// An already-done ctx wins outright; otherwise the select could randomly pick a ready input instead.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case ch.core.input <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Recv implements ContextChannel.
+func (ch *InfiniteChannel) Recv(ctx context.Context) (interface{}, error) {
+	select {
+	case v, open := <-ch.core.output:
+		if !open {
+			return nil, ErrClosed
+		}
+		return v, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
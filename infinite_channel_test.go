@@ -0,0 +1,47 @@
+package channels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInfiniteChannelPreservesOrderAcrossGrowth(t *testing.T) {
+	ch := NewInfiniteChannel()
+	const n = ringBufferMinCap * 3
+	for i := 0; i < n; i++ {
+		ch.In() <- i
+	}
+	ch.Close()
+
+	for i := 0; i < n; i++ {
+		v, open := <-ch.Out()
+		if !open {
+			t.Fatalf("Out() closed early after %d elements", i)
+		}
+		if v != i {
+			t.Fatalf("expected %d, got %v", i, v)
+		}
+	}
+
+	if _, open := <-ch.Out(); open {
+		t.Fatal("expected Out() to be closed once drained")
+	}
+}
+
+func TestInfiniteChannelNeverBlocksOnSend(t *testing.T) {
+	ch := NewInfiniteChannel()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			ch.In() <- i
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("In() blocked even though InfiniteChannel should never apply backpressure")
+	}
+	ch.Close()
+}
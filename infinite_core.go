@@ -0,0 +1,57 @@
+package channels
+
+// infiniteCore is the shared unbounded-buffering state machine behind InfiniteChannel and Infinite[T]: it grows a
+// ringBuffer[T] to hold whatever has been sent on input but not yet received from output, so input never blocks.
+// Keeping the logic in one generic type means it only has to be implemented, and tested, once for both facades.
+type infiniteCore[T any] struct {
+	input, output chan T
+	length        chan int
+	buffer        *ringBuffer[T]
+}
+
+func newInfiniteCore[T any]() *infiniteCore[T] {
+	c := &infiniteCore[T]{
+		input:  make(chan T),
+		output: make(chan T),
+		length: make(chan int),
+		buffer: newRingBuffer[T](),
+	}
+	go c.run()
+	return c
+}
+
+func (c *infiniteCore[T]) run() {
+	var input, output, nextInput chan T
+	nextInput = c.input
+	input = nextInput
+	var next T
+
+	for input != nil || output != nil {
+		select {
+		case elem, open := <-input:
+			if open {
+				c.buffer.push(elem)
+			} else { // input closed
+				input = nil
+				nextInput = nil
+			}
+		case output <- next:
+			c.buffer.pop()
+		case c.length <- c.buffer.Len():
+		}
+
+		if c.buffer.Len() > 0 {
+			input = nextInput
+			output = c.output
+			next = c.buffer.peek()
+		} else {
+			input = nextInput
+			output = nil
+			var zero T
+			next = zero
+		}
+	}
+
+	close(c.output)
+	close(c.length)
+}
@@ -0,0 +1,31 @@
+package channels
+
+import "errors"
+
+// OverflowPolicy determines what a bounded channel does when a send would exceed its capacity.
+type OverflowPolicy int
+
+const (
+	// Block makes In()/Send() block until the reader has drained enough room - the default, and the only
+	// policy available before OverflowPolicy was introduced.
+	Block OverflowPolicy = iota
+	// DropNewest silently discards the incoming element when the channel is full, incrementing Stats().Dropped.
+	DropNewest
+	// DropOldest evicts the oldest buffered element to make room for the incoming one, incrementing
+	// Stats().Dropped.
+	DropOldest
+	// Error makes In()/Send() block once the channel is full, exactly like Block, and TrySend returns ErrFull
+	// then. TrySend reports ErrFull the same way under Block, so the two policies behave identically.
+	Error
+)
+
+// ErrFull is returned by TrySend when v cannot be accepted without blocking, such as when the channel is at
+// capacity under the Block or Error overflow policy.
+var ErrFull = errors.New("channels: channel full")
+
+// Stats reports overflow-related counters for a channel constructed with a non-Block OverflowPolicy.
+type Stats struct {
+	// Dropped is the number of elements discarded so far because the channel was full (DropNewest/DropOldest
+	// only; always 0 for Block and Error).
+	Dropped uint64
+}
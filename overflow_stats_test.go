@@ -0,0 +1,60 @@
+package channels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsSurvivesCloseWithDropCount(t *testing.T) {
+	ch := NewBatchingChannelWithOverflow(BufferCap(1), 0, 0, DropNewest)
+	ch.In() <- 1 // fills the one slot
+	ch.In() <- 2 // dropped
+	ch.In() <- 3 // dropped
+	time.Sleep(50 * time.Millisecond)
+
+	if got := ch.Stats().Dropped; got != 2 {
+		t.Fatalf("expected 2 dropped before Close(), got %d", got)
+	}
+
+	ch.Close()
+	<-ch.Out()
+
+	if got := ch.Stats().Dropped; got != 2 {
+		t.Fatalf("expected Stats() to keep reporting 2 drops after Close(), got %d", got)
+	}
+}
+
+func TestStatsDropOldestEvictsAndCounts(t *testing.T) {
+	ch := NewBatchingChannelWithOverflow(BufferCap(2), 0, 0, DropOldest)
+	ch.In() <- 1 // fills slot 1 of 2
+	ch.In() <- 2 // fills slot 2 of 2
+	ch.In() <- 3 // 1 evicted, 3 takes its place
+	time.Sleep(50 * time.Millisecond)
+
+	if got := ch.Stats().Dropped; got != 1 {
+		t.Fatalf("expected 1 dropped, got %d", got)
+	}
+
+	batch := (<-ch.Out()).([]interface{})
+	if len(batch) != 2 || batch[0] != 2 || batch[1] != 3 {
+		t.Fatalf("expected surviving batch [2 3], got %v", batch)
+	}
+	ch.Close()
+}
+
+func TestStatsFullBatchFlushesBeforeMaxDelayUnderDropPolicy(t *testing.T) {
+	ch := NewBatchingChannelWithOverflow(BufferCap(2), 5*time.Second, 0, DropOldest)
+	ch.In() <- 1
+	ch.In() <- 2
+
+	select {
+	case batch := <-ch.Out():
+		got := batch.([]interface{})
+		if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+			t.Fatalf("unexpected batch %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a full DropOldest batch should flush immediately, not wait for maxDelay")
+	}
+	ch.Close()
+}
@@ -0,0 +1,61 @@
+package channels
+
+// ringBuffer is a growable ring-buffered queue of T. It backs InfiniteChannel and Infinite[T], replacing a slice
+// that would otherwise be repeatedly appended to and then discarded wholesale, which otherwise means O(n) garbage
+// churn and large steady-state allocations whenever producers burst ahead of a slower consumer. Capacity always
+// doubles once full (into a new backing array), mirroring the circular-queue design the Go runtime uses internally for hchan.
+type ringBuffer[T any] struct {
+	buf        []T
+	head, tail int
+	count      int
+}
+
+// ringBufferMinCap is the capacity a ringBuffer starts out with.
+const ringBufferMinCap = 16
+
+func newRingBuffer[T any]() *ringBuffer[T] {
+	return &ringBuffer[T]{buf: make([]T, ringBufferMinCap)}
+}
+
+func (r *ringBuffer[T]) Len() int {
+	return r.count
+}
+
+// push enqueues v at the tail, growing the buffer first if it is full.
+func (r *ringBuffer[T]) push(v T) {
+	if r.count == len(r.buf) {
+		r.grow()
+	}
+	r.buf[r.tail] = v
+	r.tail = (r.tail + 1) % len(r.buf)
+	r.count++
+}
+
+// peek returns the element at the head without removing it. It must not be called on an empty buffer.
+func (r *ringBuffer[T]) peek() T {
+	return r.buf[r.head]
+}
+
+// pop removes and returns the element at the head. It must not be called on an empty buffer.
+func (r *ringBuffer[T]) pop() T {
+	var zero T
+	v := r.buf[r.head]
+	r.buf[r.head] = zero // avoid keeping a stale reference alive in the backing array
+	r.head = (r.head + 1) % len(r.buf)
+	r.count--
+	return v
+}
+
+// grow doubles the capacity, copying the (possibly wrapped) live range to the start of the new backing array.
+func (r *ringBuffer[T]) grow() {
+	newBuf := make([]T, len(r.buf)*2)
+	if r.head < r.tail {
+		copy(newBuf, r.buf[r.head:r.tail])
+	} else {
+		n := copy(newBuf, r.buf[r.head:])
+		copy(newBuf[n:], r.buf[:r.tail])
+	}
+	r.buf = newBuf
+	r.head = 0
+	r.tail = r.count
+}